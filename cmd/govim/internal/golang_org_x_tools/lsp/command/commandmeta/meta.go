@@ -0,0 +1,238 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package commandmeta statically extracts metadata about gopls' commands
+// from their declarations in the command.Interface type, so that it can be
+// consumed without having to parse ad-hoc command literals by hand.
+//
+// Every command is a method on command.Interface whose first parameter is
+// a context.Context, whose remaining parameters are the command's
+// arguments, and which returns either (T, error) or error. The doc comment
+// on the method is expected to start with "<Name>: <Title>", followed by a
+// blank line and a longer description; this mirrors the convention used
+// elsewhere in gopls for documenting generated API surface.
+package commandmeta
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+)
+
+// Command describes a single method of command.Interface.
+type Command struct {
+	// MethodName is the Go method name, e.g. "ApplyFix".
+	MethodName string
+	// Name is the command's identifier, as sent over
+	// workspace/executeCommand, e.g. "apply_fix".
+	Name string
+	// Title is a short human-readable summary of the command.
+	Title string
+	// Doc is the remainder of the doc comment, after the Name/Title line.
+	Doc string
+	// Args describes the command's parameters, in order, excluding the
+	// leading context.Context.
+	Args []*Field
+	// Result describes the non-error return value, or nil if the command
+	// only returns an error.
+	Result *Field
+}
+
+// Field describes a single argument or result value.
+type Field struct {
+	// Name is the parameter name as written in the interface method, or a
+	// synthesized "arg%d" if the method left it unnamed.
+	Name string
+	// Doc is the parameter's leading doc comment or trailing line
+	// comment, or the empty string if it has neither.
+	Doc string
+	// Type is the Go type of the field.
+	Type types.Type
+}
+
+// Load loads the package at pkgPath and extracts a Command for every method
+// of the exported interface named ifaceName.
+func Load(pkgPath, ifaceName string) ([]*Command, error) {
+	pkgs, err := packages.Load(
+		&packages.Config{
+			Mode: packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax | packages.NeedDeps,
+		},
+		pkgPath,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no packages found for %q", pkgPath)
+	}
+	pkg := pkgs[0]
+
+	obj := pkg.Types.Scope().Lookup(ifaceName)
+	if obj == nil {
+		return nil, fmt.Errorf("no interface %q in %q", ifaceName, pkgPath)
+	}
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("%q is not an interface", ifaceName)
+	}
+
+	file, err := fileForPos(pkg, obj.Pos())
+	if err != nil {
+		return nil, err
+	}
+
+	// go/parser only attaches Doc/Comment to *ast.Field for struct and
+	// interface member lists, not for function parameter lists, so a
+	// parameter's comment has to be recovered from the file's comments by
+	// position instead.
+	cmap := ast.NewCommentMap(pkg.Fset, file, file.Comments)
+
+	var commands []*Command
+	for i := 0; i < iface.NumExplicitMethods(); i++ {
+		m := iface.ExplicitMethod(i)
+
+		path, _ := astutil.PathEnclosingInterval(file, m.Pos(), m.Pos())
+		astField, ok := path[1].(*ast.Field)
+		if !ok {
+			return nil, fmt.Errorf("unexpected AST path for method %v", m)
+		}
+
+		c, err := loadCommand(m, astField, cmap)
+		if err != nil {
+			return nil, fmt.Errorf("loading command %v: %v", m.Name(), err)
+		}
+		commands = append(commands, c)
+	}
+	return commands, nil
+}
+
+func loadCommand(m *types.Func, astField *ast.Field, cmap ast.CommentMap) (*Command, error) {
+	name, title, doc := splitDoc(m.Name(), astField.Doc.Text())
+
+	sig := m.Type().(*types.Signature)
+	if sig.Params().Len() == 0 || sig.Params().At(0).Type().String() != "context.Context" {
+		return nil, fmt.Errorf("first parameter must be context.Context")
+	}
+
+	astFuncType, ok := astField.Type.(*ast.FuncType)
+	if !ok {
+		return nil, fmt.Errorf("unexpected field type %T", astField.Type)
+	}
+
+	c := &Command{
+		MethodName: m.Name(),
+		Name:       name,
+		Title:      title,
+		Doc:        doc,
+	}
+	for i := 1; i < sig.Params().Len(); i++ {
+		param := sig.Params().At(i)
+		field, name := paramFieldAndName(param, astFuncType, i)
+		c.Args = append(c.Args, &Field{
+			Name: name,
+			Doc:  paramDoc(cmap, field),
+			Type: param.Type(),
+		})
+	}
+
+	switch sig.Results().Len() {
+	case 1:
+		if !isError(sig.Results().At(0).Type()) {
+			return nil, fmt.Errorf("single return value must be error")
+		}
+	case 2:
+		if !isError(sig.Results().At(1).Type()) {
+			return nil, fmt.Errorf("second return value must be error")
+		}
+		c.Result = &Field{Name: "result", Type: sig.Results().At(0).Type()}
+	default:
+		return nil, fmt.Errorf("must return (T, error) or error, got %d results", sig.Results().Len())
+	}
+	return c, nil
+}
+
+// splitDoc splits a method's doc comment of the form "Name: Title\n\nDoc..."
+// into its parts, falling back to the method name if the comment doesn't
+// follow the convention.
+func splitDoc(methodName, doc string) (name, title, rest string) {
+	name = lowerFirst(methodName)
+	firstLine, remainder, _ := strings.Cut(strings.TrimSpace(doc), "\n")
+	if n, t, ok := strings.Cut(firstLine, ":"); ok {
+		name = lowerFirst(strings.TrimSpace(n))
+		title = strings.TrimSpace(t)
+		return name, title, strings.TrimSpace(remainder)
+	}
+	return name, firstLine, strings.TrimSpace(remainder)
+}
+
+// paramFieldAndName returns the *ast.Field syntax node covering the i'th
+// parameter (accounting for grouped names like "a, b int") along with its
+// name: the name declared for it, or a synthesized "arg%d" if the
+// parameter is unnamed.
+func paramFieldAndName(param *types.Var, fn *ast.FuncType, i int) (*ast.Field, string) {
+	idx := i
+	for _, field := range fn.Params.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		if idx < n {
+			name := param.Name()
+			if name == "" && idx < len(field.Names) {
+				name = field.Names[idx].Name
+			}
+			if name == "" {
+				name = fmt.Sprintf("arg%d", i)
+			}
+			return field, name
+		}
+		idx -= n
+	}
+	return nil, fmt.Sprintf("arg%d", i)
+}
+
+// paramDoc returns the documentation for a parameter field: every comment
+// group associated with it (typically a leading doc comment or a trailing
+// line comment), joined by newlines.
+//
+// go/parser does not populate Field.Doc or Field.Comment for parameter
+// list fields (unlike struct and interface member fields), so the
+// comment has to be looked up in cmap, which associates comments with
+// AST nodes by position instead.
+func paramDoc(cmap ast.CommentMap, field *ast.Field) string {
+	if field == nil {
+		return ""
+	}
+	var docs []string
+	for _, g := range cmap[field] {
+		docs = append(docs, strings.TrimSpace(g.Text()))
+	}
+	return strings.TrimSpace(strings.Join(docs, "\n"))
+}
+
+func isError(t types.Type) bool {
+	return t.String() == "error"
+}
+
+func lowerFirst(x string) string {
+	if x == "" {
+		return x
+	}
+	return strings.ToLower(x[:1]) + x[1:]
+}
+
+func fileForPos(pkg *packages.Package, pos token.Pos) (*ast.File, error) {
+	fset := pkg.Fset
+	for _, f := range pkg.Syntax {
+		if fset.Position(f.Pos()).Filename == fset.Position(pos).Filename {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("no file for pos %v", pos)
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commandmeta
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestSplitDoc(t *testing.T) {
+	tests := []struct {
+		methodName string
+		doc        string
+		wantName   string
+		wantTitle  string
+		wantRest   string
+	}{
+		{
+			methodName: "ApplyFix",
+			doc:        "ApplyFix: Apply a fix\n\nApplyFix applies a fix to a region of source code.",
+			wantName:   "applyFix",
+			wantTitle:  "Apply a fix",
+			wantRest:   "ApplyFix applies a fix to a region of source code.",
+		},
+		{
+			methodName: "RunTests",
+			doc:        "",
+			wantName:   "runTests",
+			wantTitle:  "",
+			wantRest:   "",
+		},
+		{
+			methodName: "Generate",
+			doc:        "this does not follow the name-and-title convention",
+			wantName:   "generate",
+			wantTitle:  "this does not follow the name-and-title convention",
+			wantRest:   "",
+		},
+	}
+	for _, tt := range tests {
+		gotName, gotTitle, gotRest := splitDoc(tt.methodName, tt.doc)
+		if gotName != tt.wantName || gotTitle != tt.wantTitle || gotRest != tt.wantRest {
+			t.Errorf("splitDoc(%q, %q) = (%q, %q, %q), want (%q, %q, %q)",
+				tt.methodName, tt.doc, gotName, gotTitle, gotRest, tt.wantName, tt.wantTitle, tt.wantRest)
+		}
+	}
+}
+
+func TestParamDoc(t *testing.T) {
+	const src = `package p
+
+func F(
+	// a is a leading comment.
+	a int,
+	b string, // b is a trailing comment.
+	c bool,
+) {
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := file.Decls[0].(*ast.FuncDecl).Type.Params.List
+	cmap := ast.NewCommentMap(fset, file, file.Comments)
+
+	tests := []struct {
+		field *ast.Field
+		want  string
+	}{
+		{params[0], "a is a leading comment."},
+		{params[1], "b is a trailing comment."},
+		{params[2], ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := paramDoc(cmap, tt.field); got != tt.want {
+			t.Errorf("paramDoc(cmap, %v) = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+// APIJSON is a JSON-encodable representation of gopls' external-facing
+// API: its settings, commands and code lenses. genapijson builds one of
+// these by inspecting this package, then renders it as documentation,
+// a JSON Schema, and the generated Go variables in this package.
+type APIJSON struct {
+	Options  map[string][]*OptionJSON
+	Commands []*CommandJSON
+	Lenses   []*LensJSON
+}
+
+// OptionJSON describes a single user-configurable setting.
+type OptionJSON struct {
+	Name       string
+	Type       string
+	Doc        string
+	EnumValues []EnumValue
+	Default    string
+}
+
+// EnumValue describes one of the values a string-typed enum setting may
+// take.
+type EnumValue struct {
+	Value string
+	Doc   string
+}
+
+// CommandJSON describes a single command that can be sent via
+// workspace/executeCommand.
+type CommandJSON struct {
+	Command string
+	Title   string
+	Doc     string
+	// Args describes the command's parameters, in the order they must
+	// appear in workspace/executeCommand's Arguments.
+	Args []*CommandArgJSON
+	// Result is the Go type of the command's non-error return value, or
+	// the empty string if the command only returns an error.
+	Result string
+}
+
+// CommandArgJSON describes a single argument to a command.
+type CommandArgJSON struct {
+	Name string
+	Type string
+	Doc  string
+}
+
+// LensJSON describes a single code lens.
+type LensJSON struct {
+	Lens  string
+	Title string
+	Doc   string
+}
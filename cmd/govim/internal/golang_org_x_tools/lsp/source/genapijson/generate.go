@@ -19,14 +19,22 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sanity-io/litter"
 	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/packages"
+	"github.com/govim/govim/cmd/govim/internal/golang_org_x_tools/lsp/command/commandmeta"
 	"github.com/govim/govim/cmd/govim/internal/golang_org_x_tools/lsp/mod"
 	"github.com/govim/govim/cmd/govim/internal/golang_org_x_tools/lsp/source"
 )
 
 var (
-	output = flag.String("output", "", "output file")
+	output     = flag.String("output", "", "output file for the generated source.GeneratedAPIJSON")
+	settingsMD = flag.String("settingsmd", "", "output file for the generated settings.md")
+	commandsMD = flag.String("commandsmd", "", "output file for the generated commands.md")
+	lensesMD   = flag.String("lensesmd", "", "output file for the generated lenses.md")
+	vimHelp    = flag.String("vimhelp", "", "output file for the generated govim :help reference")
+	schema     = flag.String("schema", "", "output file for a JSON Schema describing gopls settings")
+	enumsOut   = flag.String("enumsoutput", "", "output file for generated enum Marshal/UnmarshalJSON methods")
 )
 
 func main() {
@@ -38,28 +46,57 @@ func main() {
 }
 
 func doMain() error {
-	out := os.Stdout
-	if *output != "" {
-		var err error
-		out, err = os.OpenFile(*output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0777)
-		if err != nil {
+	api, pkg, err := loadAPI()
+	if err != nil {
+		return err
+	}
+
+	enumsCode, err := generateEnumCode(pkg)
+	if err != nil {
+		return err
+	}
+
+	writes := []struct {
+		path    string
+		content []byte
+	}{
+		{*output, generateAPIJSON(api)},
+		{*settingsMD, generateSettingsMarkdown(api)},
+		{*commandsMD, generateCommandsMarkdown(api)},
+		{*lensesMD, generateLensesMarkdown(api)},
+		{*vimHelp, generateVimHelp(api)},
+		{*schema, generateSchema(api)},
+		{*enumsOut, enumsCode},
+	}
+	for _, w := range writes {
+		if w.path == "" {
+			continue
+		}
+		if err := writeFile(w.path, w.content); err != nil {
 			return err
 		}
-		defer out.Close()
 	}
+	return nil
+}
 
-	content, err := generate()
+func writeFile(path string, content []byte) error {
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0777)
 	if err != nil {
 		return err
 	}
 	if _, err := out.Write(content); err != nil {
+		out.Close()
 		return err
 	}
-
 	return out.Close()
 }
 
-func generate() ([]byte, error) {
+// loadAPI loads the package describing gopls' options, commands and lenses
+// and assembles it into the single structure that genapijson's API outputs
+// (the generated Go source, the Markdown and Vim :help references and the
+// JSON Schema) are rendered from. It also returns the loaded package, from
+// which the enum MarshalJSON/UnmarshalJSON methods are generated.
+func loadAPI() (*source.APIJSON, *packages.Package, error) {
 	pkgs, err := packages.Load(
 		&packages.Config{
 			Mode: packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax | packages.NeedDeps,
@@ -67,7 +104,7 @@ func generate() ([]byte, error) {
 		"github.com/govim/govim/cmd/govim/internal/golang_org_x_tools/lsp/source",
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	pkg := pkgs[0]
 
@@ -82,15 +119,15 @@ func generate() ([]byte, error) {
 	} {
 		opts, err := loadOptions(cat, pkg)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		catName := strings.TrimSuffix(cat.Type().Name(), "Options")
 		api.Options[catName] = opts
 	}
 
-	api.Commands, err = loadCommands(pkg)
+	api.Commands, err = loadCommands()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	api.Lenses = loadLenses(api.Commands)
 
@@ -99,13 +136,292 @@ func generate() ([]byte, error) {
 		c.Command = source.CommandPrefix + c.Command
 	}
 
+	return api, pkg, nil
+}
+
+// litterOptions configures how generated Go literals are pretty-printed:
+// HomePackage suppresses the "source." qualifier on types that already
+// live in the generated file's own package.
+var litterOptions = litter.Options{
+	HomePackage: "source",
+}
+
+// generateAPIJSON renders api as the GeneratedAPIJSON string constant (its
+// existing name and type, for callers that unmarshal it directly), plus
+// typed Go variables (GeneratedOptions, GeneratedCommands, GeneratedLenses)
+// for callers that want direct access without a JSON round-trip.
+func generateAPIJSON(api *source.APIJSON) []byte {
 	marshaled, err := json.Marshal(api)
 	if err != nil {
-		return nil, err
+		panic(fmt.Sprintf("marshaling api: %v", err))
 	}
 	buf := bytes.NewBuffer(nil)
-	fmt.Fprintf(buf, "// Code generated by \"github.com/govim/govim/cmd/govim/internal/golang_org_x_tools/lsp/source/genapijson\"; DO NOT EDIT.\n\npackage source\n\nconst GeneratedAPIJSON = %q\n", string(marshaled))
-	return buf.Bytes(), nil
+	fmt.Fprintf(buf, "// Code generated by \"github.com/govim/govim/cmd/govim/internal/golang_org_x_tools/lsp/source/genapijson\"; DO NOT EDIT.\n\npackage source\n\nconst GeneratedAPIJSON = %q\n\n", string(marshaled))
+	fmt.Fprintf(buf, "var GeneratedOptions = %s\n\n", litterOptions.Sdump(api.Options))
+	fmt.Fprintf(buf, "var GeneratedCommands = %s\n\n", litterOptions.Sdump(api.Commands))
+	fmt.Fprintf(buf, "var GeneratedLenses = %s\n", litterOptions.Sdump(api.Lenses))
+	return buf.Bytes()
+}
+
+// jsonSchemaDoc is the root of a JSON Schema draft-07 document describing
+// the settings a client may send gopls.
+type jsonSchemaDoc struct {
+	Schema     string                 `json:"$schema"`
+	Title      string                 `json:"title"`
+	Type       string                 `json:"type"`
+	Properties map[string]*jsonSchema `json:"properties"`
+}
+
+// jsonSchema is a single node of a JSON Schema document, covering the
+// subset of draft-07 that gopls' option types need.
+type jsonSchema struct {
+	Type                 interface{}            `json:"type,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Default              json.RawMessage        `json:"default,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	EnumDescriptions     []string               `json:"enumDescriptions,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	Experimental         bool                   `json:"x-experimental,omitempty"`
+}
+
+// durationPattern matches the syntax accepted by time.ParseDuration, e.g.
+// "100ms" or "1h30m".
+const durationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// generateSchema renders api's settings as a JSON Schema draft-07 document.
+func generateSchema(api *source.APIJSON) []byte {
+	doc := &jsonSchemaDoc{
+		Schema:     "http://json-schema.org/draft-07/schema#",
+		Title:      "gopls settings",
+		Type:       "object",
+		Properties: map[string]*jsonSchema{},
+	}
+	for catName, opts := range api.Options {
+		experimental := catName == "Experimental"
+		for _, opt := range opts {
+			doc.Properties[opt.Name] = optionSchema(opt, experimental)
+		}
+	}
+	marshaled, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		// doc is built entirely from values that round-trip through
+		// encoding/json above, so this can't happen.
+		panic(fmt.Sprintf("marshaling schema: %v", err))
+	}
+	return append(marshaled, '\n')
+}
+
+// optionSchema maps a single OptionJSON to the JSON Schema node that
+// validates it.
+func optionSchema(opt *source.OptionJSON, experimental bool) *jsonSchema {
+	node := typeSchema(opt.Type)
+	node.Description = mdDoc(opt.Doc)
+	node.Default = json.RawMessage(opt.Default)
+	node.Experimental = experimental
+
+	if len(opt.EnumValues) > 0 {
+		for _, v := range opt.EnumValues {
+			node.Enum = append(node.Enum, strings.Trim(v.Value, `"`))
+			node.EnumDescriptions = append(node.EnumDescriptions, mdDoc(v.Doc))
+		}
+	}
+	return node
+}
+
+// integerTypes holds the exact Go type names that map to a JSON Schema
+// "integer". A prefix check like strings.HasPrefix(typ, "int") would also
+// match unrelated types such as "interface{}", so it must be an exact set.
+var integerTypes = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"uintptr": true,
+}
+
+// typeSchema maps a Go type, as printed by types.Type.String, to the JSON
+// Schema node describing its shape. It does not set Description, Default
+// or the enum fields; callers fill those in from the surrounding
+// OptionJSON.
+func typeSchema(typ string) *jsonSchema {
+	switch {
+	case typ == "enum":
+		return &jsonSchema{Type: "string"}
+	case typ == "time.Duration":
+		return &jsonSchema{Type: "string", Pattern: durationPattern}
+	case typ == "bool":
+		return &jsonSchema{Type: "boolean"}
+	case typ == "string":
+		return &jsonSchema{Type: "string"}
+	case integerTypes[typ]:
+		return &jsonSchema{Type: "integer"}
+	case typ == "float64" || typ == "float32":
+		return &jsonSchema{Type: "number"}
+	case strings.HasPrefix(typ, "[]"):
+		return &jsonSchema{Type: "array", Items: typeSchema(typ[len("[]"):])}
+	case strings.HasPrefix(typ, "map["):
+		// Settings maps are all string-keyed; the value type follows the
+		// closing bracket of the key type.
+		if i := strings.Index(typ, "]"); i >= 0 {
+			return &jsonSchema{Type: "object", AdditionalProperties: typeSchema(typ[i+1:])}
+		}
+		return &jsonSchema{Type: "object"}
+	default:
+		// Unknown or unexported types (e.g. structs) are left unconstrained
+		// beyond being present; this keeps the generator from having to
+		// track every internal type gopls might introduce.
+		return &jsonSchema{}
+	}
+}
+
+// settingCategories lists the option categories in the order they should be
+// documented, along with the heading to use for each.
+var settingCategories = []struct {
+	key     string
+	heading string
+}{
+	{"User", "User settings"},
+	{"Experimental", "Experimental settings"},
+	{"Debugging", "Debugging settings"},
+}
+
+// generateSettingsMarkdown renders api's options as a Markdown reference,
+// grouped by category and then by the dotted hierarchy of each setting's
+// name (e.g. "build.xxx" is documented as a "build" subsection).
+func generateSettingsMarkdown(api *source.APIJSON) []byte {
+	buf := bytes.NewBuffer(nil)
+	fmt.Fprintf(buf, "%s# gopls settings\n\nThis file is generated by genapijson; DO NOT EDIT.\n", genHTMLComment())
+	for _, cat := range settingCategories {
+		opts := api.Options[cat.key]
+		if len(opts) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "\n## %s\n", cat.heading)
+		for _, sec := range groupByHierarchy(opts) {
+			if sec.section != "" {
+				fmt.Fprintf(buf, "\n### %s\n", sec.section)
+			}
+			for _, opt := range sec.opts {
+				fmt.Fprintf(buf, "\n#### `%s`\n\n%s\n\nDefault: `%s`.\n", opt.Name, mdDoc(opt.Doc), opt.Default)
+				if len(opt.EnumValues) > 0 {
+					fmt.Fprintf(buf, "\nAccepted values:\n\n")
+					for _, v := range opt.EnumValues {
+						fmt.Fprintf(buf, "* %s\n", mdDoc(v.Doc))
+					}
+				}
+			}
+		}
+	}
+	return buf.Bytes()
+}
+
+// generateCommandsMarkdown renders api's commands as a Markdown reference.
+func generateCommandsMarkdown(api *source.APIJSON) []byte {
+	buf := bytes.NewBuffer(nil)
+	fmt.Fprintf(buf, "%s# gopls commands\n\nThis file is generated by genapijson; DO NOT EDIT.\n", genHTMLComment())
+	for _, cmd := range api.Commands {
+		fmt.Fprintf(buf, "\n## `%s`: %s\n\n%s\n", cmd.Command, cmd.Title, mdDoc(cmd.Doc))
+	}
+	return buf.Bytes()
+}
+
+// generateLensesMarkdown renders api's code lenses as a Markdown reference.
+func generateLensesMarkdown(api *source.APIJSON) []byte {
+	buf := bytes.NewBuffer(nil)
+	fmt.Fprintf(buf, "%s# gopls code lenses\n\nThis file is generated by genapijson; DO NOT EDIT.\n", genHTMLComment())
+	for _, lens := range api.Lenses {
+		fmt.Fprintf(buf, "\n## `%s`: %s\n\n%s\n", lens.Lens, lens.Title, mdDoc(lens.Doc))
+	}
+	return buf.Bytes()
+}
+
+// generateVimHelp renders api as a Vim :help-formatted reference, suitable
+// for placing under cmd/govim/config and indexing with :helptags. It covers
+// the same settings, commands and lenses as the Markdown outputs, using Vim
+// help conventions (|tags|, 'option' quoting) in place of Markdown's.
+func generateVimHelp(api *source.APIJSON) []byte {
+	buf := bytes.NewBuffer(nil)
+	fmt.Fprintf(buf, "*govim-config.txt*\tGenerated reference for govim/gopls configuration\n\n")
+	fmt.Fprintf(buf, "This file is generated by genapijson; DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "==============================================================================\n")
+	fmt.Fprintf(buf, "SETTINGS%*s*govim-settings*\n\n", 68, "")
+	for _, cat := range settingCategories {
+		opts := api.Options[cat.key]
+		if len(opts) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "%s~\n", cat.heading)
+		for _, sec := range groupByHierarchy(opts) {
+			for _, opt := range sec.opts {
+				fmt.Fprintf(buf, "\n'%s'\t*govim-setting-%s*\n", opt.Name, opt.Name)
+				fmt.Fprintf(buf, "\tDefault: %s\n\n%s\n", opt.Default, vimDoc(opt.Doc))
+			}
+		}
+	}
+	fmt.Fprintf(buf, "\n==============================================================================\n")
+	fmt.Fprintf(buf, "COMMANDS%*s*govim-commands*\n\n", 68, "")
+	for _, cmd := range api.Commands {
+		fmt.Fprintf(buf, "'%s'\t*govim-command-%s*\n\n%s\n\n", cmd.Command, cmd.Command, vimDoc(cmd.Doc))
+	}
+	fmt.Fprintf(buf, "\n==============================================================================\n")
+	fmt.Fprintf(buf, "CODE LENSES%*s*govim-lenses*\n\n", 65, "")
+	for _, lens := range api.Lenses {
+		fmt.Fprintf(buf, "'%s'\t*govim-lens-%s*\n\n%s\n\n", lens.Lens, lens.Lens, vimDoc(lens.Doc))
+	}
+	fmt.Fprintf(buf, "\n vim:tw=78:ts=8:noet:ft=help:norl:\n")
+	return buf.Bytes()
+}
+
+func genHTMLComment() string {
+	return "<!-- Code generated by \"github.com/govim/govim/cmd/govim/internal/golang_org_x_tools/lsp/source/genapijson\"; DO NOT EDIT. -->\n\n"
+}
+
+// groupByHierarchy splits opts into sections according to the dotted
+// prefix of each option's name (e.g. "ui.diagnostic.annotations" belongs to
+// the "ui.diagnostic" section), preserving the original order of opts
+// within and across sections.
+func groupByHierarchy(opts []*source.OptionJSON) []struct {
+	section string
+	opts    []*source.OptionJSON
+} {
+	var sections []struct {
+		section string
+		opts    []*source.OptionJSON
+	}
+	index := map[string]int{}
+	for _, opt := range opts {
+		section := ""
+		if i := strings.LastIndex(opt.Name, "."); i >= 0 {
+			section = opt.Name[:i]
+		}
+		idx, ok := index[section]
+		if !ok {
+			idx = len(sections)
+			index[section] = idx
+			sections = append(sections, struct {
+				section string
+				opts    []*source.OptionJSON
+			}{section: section})
+		}
+		sections[idx].opts = append(sections[idx].opts, opt)
+	}
+	return sections
+}
+
+// mdDoc rewrites backticked identifiers in doc for rendering in Markdown.
+// Markdown already treats backticks as inline code, so this is close to the
+// identity function; it exists so Markdown- and Vim-specific rewriting stay
+// symmetrical and in one place.
+func mdDoc(doc string) string {
+	return strings.TrimSpace(doc)
+}
+
+// vimDoc rewrites backticked identifiers in doc for rendering as Vim
+// :help text, where inline code is conventionally written as 'foo' rather
+// than `foo`.
+func vimDoc(doc string) string {
+	doc = strings.TrimSpace(doc)
+	return strings.ReplaceAll(doc, "`", "'")
 }
 
 func loadOptions(category reflect.Value, pkg *packages.Package) ([]*source.OptionJSON, error) {
@@ -210,6 +526,77 @@ func loadEnums(pkg *packages.Package) (map[types.Type][]source.EnumValue, error)
 	return enums, nil
 }
 
+// enumType is the subset of an enum's type information needed to generate
+// its MarshalJSON/UnmarshalJSON methods: the exported type name, and the
+// case labels for each of its values.
+type enumType struct {
+	Name    string
+	Members []string // Go literals, e.g. `"foo"`, suitable as switch case labels.
+}
+
+// loadEnumTypes finds every string-kinded named type in pkg that has one or
+// more associated constants, in the same way loadEnums does, but keeps the
+// type identity (rather than just its documentation) so that code can be
+// generated for it.
+func loadEnumTypes(pkg *packages.Package) []*enumType {
+	var order []*types.Named
+	byType := map[*types.Named]*enumType{}
+	for _, name := range pkg.Types.Scope().Names() {
+		obj := pkg.Types.Scope().Lookup(name)
+		cnst, ok := obj.(*types.Const)
+		if !ok {
+			continue
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		basic, ok := named.Underlying().(*types.Basic)
+		if !ok || basic.Info()&types.IsString == 0 {
+			continue
+		}
+		et, ok := byType[named]
+		if !ok {
+			et = &enumType{Name: named.Obj().Name()}
+			byType[named] = et
+			order = append(order, named)
+		}
+		et.Members = append(et.Members, cnst.Val().ExactString())
+	}
+	var enums []*enumType
+	for _, named := range order {
+		enums = append(enums, byType[named])
+	}
+	return enums
+}
+
+// generateEnumCode generates MarshalJSON and UnmarshalJSON methods for
+// every settings enum type in pkg.
+func generateEnumCode(pkg *packages.Package) ([]byte, error) {
+	enums := loadEnumTypes(pkg)
+
+	buf := bytes.NewBuffer(nil)
+	fmt.Fprintf(buf, "// Code generated by \"github.com/govim/govim/cmd/govim/internal/golang_org_x_tools/lsp/source/genapijson\"; DO NOT EDIT.\n\npackage source\n\nimport (\n\t\"encoding/json\"\n\t\"fmt\"\n)\n")
+
+	for _, e := range enums {
+		fmt.Fprintf(buf, "\nfunc (t %s) MarshalJSON() ([]byte, error) {\n\treturn json.Marshal(string(t))\n}\n", e.Name)
+
+		fmt.Fprintf(buf, "\nfunc (t *%s) UnmarshalJSON(data []byte) error {\n\tvar s string\n\tif err := json.Unmarshal(data, &s); err != nil {\n\t\treturn err\n\t}\n\tswitch s {\n\tcase %s:\n\t\t*t = %s(s)\n\t\treturn nil\n\tdefault:\n\t\treturn fmt.Errorf(\"invalid value %%q for %s, must be one of: %s\", s)\n\t}\n}\n",
+			e.Name, strings.Join(e.Members, ", "), e.Name, e.Name, strings.Join(unquoteAll(e.Members), ", "))
+	}
+	return buf.Bytes(), nil
+}
+
+// unquoteAll trims the surrounding double quotes from each Go string
+// literal in vs, for use in a human-readable list of valid values.
+func unquoteAll(vs []string) []string {
+	out := make([]string, len(vs))
+	for i, v := range vs {
+		out[i] = strings.Trim(v, `"`)
+	}
+	return out
+}
+
 // valueDoc transforms a docstring documenting an constant identifier to a
 // docstring documenting its value.
 //
@@ -227,78 +614,62 @@ func valueDoc(name, value, doc string) string {
 	return fmt.Sprintf("`%s`: %s", value, doc)
 }
 
-func loadCommands(pkg *packages.Package) ([]*source.CommandJSON, error) {
-	// The code that defines commands is much more complicated than the
-	// code that defines options, so reading comments for the Doc is very
-	// fragile. If this causes problems, we should switch to a dynamic
-	// approach and put the doc in the Commands struct rather than reading
-	// from the source code.
+// commandInterfacePkg and commandInterfaceName identify the interface whose
+// methods define gopls' commands. Each method's signature (leading
+// context.Context, remaining parameters, and (T, error)/error result)
+// supplies the argument and result schema; the method's doc comment
+// supplies the name, title and prose documentation. This replaced an
+// earlier approach that walked the AST of a hand-written Commands slice,
+// which broke every time that slice's shape changed.
+const (
+	commandInterfacePkg  = "github.com/govim/govim/cmd/govim/internal/golang_org_x_tools/lsp/command"
+	commandInterfaceName = "Interface"
+)
 
-	// Find the Commands slice.
-	typesSlice := pkg.Types.Scope().Lookup("Commands")
-	f, err := fileForPos(pkg, typesSlice.Pos())
+func loadCommands() ([]*source.CommandJSON, error) {
+	cmds, err := commandmeta.Load(commandInterfacePkg, commandInterfaceName)
 	if err != nil {
-		return nil, err
-	}
-	path, _ := astutil.PathEnclosingInterval(f, typesSlice.Pos(), typesSlice.Pos())
-	vspec := path[1].(*ast.ValueSpec)
-	var astSlice *ast.CompositeLit
-	for i, name := range vspec.Names {
-		if name.Name == "Commands" {
-			astSlice = vspec.Values[i].(*ast.CompositeLit)
-		}
+		return nil, fmt.Errorf("loading command metadata: %v", err)
 	}
 
 	var commands []*source.CommandJSON
-
-	// Parse the objects it contains.
-	for _, elt := range astSlice.Elts {
-		// Find the composite literal of the Command.
-		typesCommand := pkg.TypesInfo.ObjectOf(elt.(*ast.Ident))
-		path, _ := astutil.PathEnclosingInterval(f, typesCommand.Pos(), typesCommand.Pos())
-		vspec := path[1].(*ast.ValueSpec)
-
-		var astCommand ast.Expr
-		for i, name := range vspec.Names {
-			if name.Name == typesCommand.Name() {
-				astCommand = vspec.Values[i]
-			}
-		}
-
-		// Read the Name and Title fields of the literal.
-		var name, title string
-		ast.Inspect(astCommand, func(n ast.Node) bool {
-			kv, ok := n.(*ast.KeyValueExpr)
-			if ok {
-				k := kv.Key.(*ast.Ident).Name
-				switch k {
-				case "Name":
-					name = strings.Trim(kv.Value.(*ast.BasicLit).Value, `"`)
-				case "Title":
-					title = strings.Trim(kv.Value.(*ast.BasicLit).Value, `"`)
-				}
-			}
-			return true
-		})
-
+	for _, cmd := range cmds {
+		title := cmd.Title
 		if title == "" {
-			title = name
+			title = cmd.Name
 		}
-
-		// Conventionally, the doc starts with the name of the variable.
-		// Replace it with the name of the command.
-		doc := vspec.Doc.Text()
-		doc = strings.Replace(doc, typesCommand.Name(), name, 1)
-
 		commands = append(commands, &source.CommandJSON{
-			Command: name,
+			Command: cmd.Name,
 			Title:   title,
-			Doc:     doc,
+			Doc:     cmd.Doc,
+			Args:    argsJSON(cmd.Args),
+			Result:  resultJSON(cmd.Result),
 		})
 	}
 	return commands, nil
 }
 
+// argsJSON converts commandmeta's argument metadata into the argument
+// schema embedded in CommandJSON.
+func argsJSON(args []*commandmeta.Field) []*source.CommandArgJSON {
+	var out []*source.CommandArgJSON
+	for _, a := range args {
+		out = append(out, &source.CommandArgJSON{
+			Name: a.Name,
+			Type: a.Type.String(),
+			Doc:  a.Doc,
+		})
+	}
+	return out
+}
+
+func resultJSON(result *commandmeta.Field) string {
+	if result == nil {
+		return ""
+	}
+	return result.Type.String()
+}
+
 func loadLenses(commands []*source.CommandJSON) []*source.LensJSON {
 	lensNames := map[string]struct{}{}
 	for k := range source.LensFuncs() {
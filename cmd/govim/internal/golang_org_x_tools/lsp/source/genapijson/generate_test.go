@@ -0,0 +1,59 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/govim/govim/cmd/govim/internal/golang_org_x_tools/lsp/source"
+)
+
+func TestTypeSchema(t *testing.T) {
+	tests := []struct {
+		typ      string
+		wantType interface{}
+	}{
+		{"int", "integer"},
+		{"int64", "integer"},
+		{"uint", "integer"},
+		{"uintptr", "integer"},
+		{"interface{}", nil},
+		{"interface {}", nil},
+		{"string", "string"},
+		{"bool", "boolean"},
+		{"float64", "number"},
+	}
+	for _, tt := range tests {
+		got := typeSchema(tt.typ).Type
+		if got != tt.wantType {
+			t.Errorf("typeSchema(%q).Type = %#v, want %#v", tt.typ, got, tt.wantType)
+		}
+	}
+}
+
+func TestGroupByHierarchy(t *testing.T) {
+	opts := []*source.OptionJSON{
+		{Name: "verboseOutput"},
+		{Name: "build.env"},
+		{Name: "build.directoryFilters"},
+		{Name: "ui.diagnostic.annotations"},
+	}
+	got := groupByHierarchy(opts)
+	wantSections := []string{"", "build", "ui.diagnostic"}
+	if len(got) != len(wantSections) {
+		t.Fatalf("groupByHierarchy returned %d sections, want %d", len(got), len(wantSections))
+	}
+	for i, want := range wantSections {
+		if got[i].section != want {
+			t.Errorf("section %d = %q, want %q", i, got[i].section, want)
+		}
+	}
+	if len(got[0].opts) != 1 || got[0].opts[0].Name != "verboseOutput" {
+		t.Errorf("section \"\" opts = %v, want [verboseOutput]", got[0].opts)
+	}
+	if len(got[1].opts) != 2 {
+		t.Errorf("section \"build\" has %d opts, want 2", len(got[1].opts))
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+//go:generate go run ./genapijson -output=api_generated.go -settingsmd=doc/settings.md -commandsmd=doc/commands.md -lensesmd=doc/lenses.md -vimhelp=../../../../config/govim-config.txt -schema=doc/settings.schema.json -enumsoutput=enums.go
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ImportShortcut controls the UI presented for a missing import: whether
+// gopls offers to jump to the import's definition, view package
+// documentation, or both.
+type ImportShortcut string
+
+const (
+	// Both offers both the definition and documentation links.
+	Both ImportShortcut = "Both"
+	// Link offers only the documentation link.
+	Link ImportShortcut = "Link"
+	// Definition offers only the definition link.
+	Definition ImportShortcut = "Definition"
+)
+
+// Options holds all of gopls' configurable settings, grouped into the
+// categories genapijson documents separately.
+type Options struct {
+	UserOptions
+	ExperimentalOptions
+	DebuggingOptions
+}
+
+// UserOptions holds the settings most gopls users will want to configure.
+type UserOptions struct {
+	// BuildFlags is the set of flags passed on to the build system when invoking it.
+	BuildFlags []string
+
+	// ImportShortcut specifies whether import statements should link to
+	// documentation or go to definitions.
+	ImportShortcut ImportShortcut
+
+	// CompletionBudget is the soft latency goal for completion requests.
+	CompletionBudget time.Duration
+}
+
+// ExperimentalOptions holds experimental settings that may change or be removed without notice.
+type ExperimentalOptions struct {
+	// TempModfile controls whether to use a temporary go.mod file when running go commands.
+	TempModfile bool
+}
+
+// DebuggingOptions holds settings only useful for debugging gopls itself.
+type DebuggingOptions struct {
+	// VerboseOutput enables additional debug logging.
+	VerboseOutput bool
+}
+
+// DefaultOptions returns the default configuration for the server.
+func DefaultOptions() Options {
+	return Options{
+		UserOptions: UserOptions{
+			ImportShortcut:   Both,
+			CompletionBudget: 100 * time.Millisecond,
+		},
+	}
+}
+
+// Set decodes settings, as received in a workspace/didChangeConfiguration
+// notification, into o, returning one error per setting that failed to
+// decode so the caller can report or ignore them individually rather than
+// aborting the whole batch.
+func (o *Options) Set(settings map[string]interface{}) []error {
+	var errs []error
+	for name, value := range settings {
+		if err := o.set(name, value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// set decodes value, as received for the workspace/didChangeConfiguration
+// setting name, into the matching field of o.
+func (o *Options) set(name string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	switch name {
+	case "buildFlags":
+		return json.Unmarshal(raw, &o.BuildFlags)
+	case "importShortcut":
+		// ImportShortcut's generated UnmarshalJSON rejects a misspelled
+		// value instead of silently leaving the field at its default.
+		return json.Unmarshal(raw, &o.ImportShortcut)
+	case "completionBudget":
+		// Durations are sent as strings such as "100ms", not as a number
+		// of nanoseconds.
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("invalid type %T for completionBudget, want a duration string", value)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		o.CompletionBudget = d
+		return nil
+	case "tempModfile":
+		return json.Unmarshal(raw, &o.TempModfile)
+	case "verboseOutput":
+		return json.Unmarshal(raw, &o.VerboseOutput)
+	default:
+		return fmt.Errorf("unknown setting %q", name)
+	}
+}
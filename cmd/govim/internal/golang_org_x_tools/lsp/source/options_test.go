@@ -0,0 +1,49 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import "testing"
+
+func TestOptionsSetImportShortcut(t *testing.T) {
+	var o Options
+	if err := o.set("importShortcut", "Definition"); err != nil {
+		t.Fatalf("set(importShortcut, Definition) = %v, want nil", err)
+	}
+	if o.ImportShortcut != Definition {
+		t.Errorf("ImportShortcut = %q, want %q", o.ImportShortcut, Definition)
+	}
+
+	if err := o.set("importShortcut", "Defnition"); err == nil {
+		t.Errorf("set(importShortcut, Defnition) = nil, want error for misspelled value")
+	}
+}
+
+func TestOptionsSet(t *testing.T) {
+	var o Options
+	errs := o.Set(map[string]interface{}{
+		"buildFlags":  []string{"-tags=foo"},
+		"notASetting": 1,
+	})
+	if len(errs) != 1 {
+		t.Fatalf("Set(...) returned %d errors, want 1: %v", len(errs), errs)
+	}
+	if want := []string{"-tags=foo"}; len(o.BuildFlags) != 1 || o.BuildFlags[0] != want[0] {
+		t.Errorf("BuildFlags = %v, want %v", o.BuildFlags, want)
+	}
+}
+
+func TestOptionsSetOtherFields(t *testing.T) {
+	var o Options
+	if err := o.set("verboseOutput", true); err != nil {
+		t.Fatalf("set(verboseOutput, true) = %v, want nil", err)
+	}
+	if !o.VerboseOutput {
+		t.Errorf("VerboseOutput = false, want true")
+	}
+
+	if err := o.set("notASetting", 1); err == nil {
+		t.Errorf("set(notASetting, 1) = nil, want error for unknown setting")
+	}
+}